@@ -0,0 +1,136 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+)
+
+// AlarmAction is the action of an alarm, as defined in RFC 5545
+// section 3.8.6.1.
+type AlarmAction string
+
+// Alarm actions.
+const (
+	ActionAudio   AlarmAction = "AUDIO"
+	ActionDisplay AlarmAction = "DISPLAY"
+	ActionEmail   AlarmAction = "EMAIL"
+)
+
+// Alarm represents a reminder or alert, as defined in RFC 5545
+// section 3.6.6.
+type Alarm struct {
+	*Component
+}
+
+// NewAlarm creates a new alarm.
+func NewAlarm() *Alarm {
+	return &Alarm{NewComponent(CompAlarm)}
+}
+
+// Alarms extracts the list of alarms contained in the event.
+func (e *Event) Alarms() []Alarm {
+	l := make([]Alarm, 0, len(e.Children))
+	for _, child := range e.Children {
+		if child.Name == CompAlarm {
+			l = append(l, Alarm{child})
+		}
+	}
+	return l
+}
+
+// Action returns the alarm's action, validating it against the known
+// AUDIO/DISPLAY/EMAIL actions.
+func (a *Alarm) Action() (AlarmAction, error) {
+	s, err := a.Props.Text(PropAction)
+	if err != nil {
+		return "", err
+	}
+
+	switch action := AlarmAction(s); action {
+	case ActionAudio, ActionDisplay, ActionEmail:
+		return action, nil
+	default:
+		return "", fmt.Errorf("ical: invalid VALARM ACTION: %q", action)
+	}
+}
+
+// SetAction sets the alarm's action.
+func (a *Alarm) SetAction(action AlarmAction) {
+	a.Props.SetText(PropAction, string(action))
+}
+
+// TriggerRelated is the value of the TRIGGER RELATED parameter, as defined
+// in RFC 5545 section 3.2.14.
+type TriggerRelated string
+
+// Trigger relations.
+const (
+	TriggerRelatedStart TriggerRelated = "START"
+	TriggerRelatedEnd   TriggerRelated = "END"
+)
+
+// Trigger is the value of a VALARM TRIGGER property: either a duration
+// relative to the parent component's start or end, or an absolute time.
+type Trigger struct {
+	Absolute time.Time      // set if the trigger is an absolute DATE-TIME
+	Duration time.Duration  // set if the trigger is relative
+	Related  TriggerRelated // only meaningful when Duration is set; defaults to START
+}
+
+// Trigger returns the alarm's trigger.
+func (a *Alarm) Trigger() (Trigger, error) {
+	prop := a.Props.Get(PropTrigger)
+	if prop == nil {
+		return Trigger{}, fmt.Errorf("ical: VALARM is missing TRIGGER")
+	}
+
+	if prop.ValueType() == ValueDateTime {
+		t, err := prop.DateTime(time.UTC)
+		if err != nil {
+			return Trigger{}, err
+		}
+		return Trigger{Absolute: t}, nil
+	}
+
+	dur, err := prop.Duration()
+	if err != nil {
+		return Trigger{}, err
+	}
+	related := TriggerRelated(prop.Params.Get(ParamRelated))
+	if related == "" {
+		related = TriggerRelatedStart
+	}
+	return Trigger{Duration: dur, Related: related}, nil
+}
+
+// SetTrigger sets the alarm's trigger.
+func (a *Alarm) SetTrigger(t Trigger) {
+	prop := NewProp(PropTrigger)
+	if !t.Absolute.IsZero() {
+		prop.SetDateTime(t.Absolute)
+		prop.Params.Set(ParamValue, string(ValueDateTime))
+	} else {
+		prop.SetDuration(t.Duration)
+		if t.Related == TriggerRelatedEnd {
+			prop.Params.Set(ParamRelated, string(TriggerRelatedEnd))
+		}
+	}
+	a.Props.Set(prop)
+}
+
+// Repeat returns the number of additional times the alarm should be
+// repeated after its initial trigger.
+func (a *Alarm) Repeat() (int, error) {
+	if prop := a.Props.Get(PropRepeat); prop != nil {
+		return prop.Int()
+	}
+	return 0, nil
+}
+
+// Duration returns the delay between repeated alarm triggers.
+func (a *Alarm) Duration() (time.Duration, error) {
+	if prop := a.Props.Get(PropDuration); prop != nil {
+		return prop.Duration()
+	}
+	return 0, nil
+}
@@ -0,0 +1,135 @@
+package ical
+
+import "fmt"
+
+// CalendarUserType is the type of calendar user, as defined in RFC 5545
+// section 3.2.3.
+type CalendarUserType string
+
+// Calendar user types.
+const (
+	CUTypeIndividual CalendarUserType = "INDIVIDUAL"
+	CUTypeGroup      CalendarUserType = "GROUP"
+	CUTypeResource   CalendarUserType = "RESOURCE"
+	CUTypeRoom       CalendarUserType = "ROOM"
+	CUTypeUnknown    CalendarUserType = "UNKNOWN"
+)
+
+// ParticipationStatus is the participation status of an attendee, as
+// defined in RFC 5545 section 3.2.12.
+type ParticipationStatus string
+
+// Participation statuses.
+const (
+	PartStatNeedsAction ParticipationStatus = "NEEDS-ACTION"
+	PartStatAccepted    ParticipationStatus = "ACCEPTED"
+	PartStatDeclined    ParticipationStatus = "DECLINED"
+	PartStatTentative   ParticipationStatus = "TENTATIVE"
+	PartStatDelegated   ParticipationStatus = "DELEGATED"
+)
+
+// Role is the participation role of an attendee, as defined in RFC 5545
+// section 3.2.16.
+type Role string
+
+// Participation roles.
+const (
+	RoleChair          Role = "CHAIR"
+	RoleReqParticipant Role = "REQ-PARTICIPANT"
+	RoleOptParticipant Role = "OPT-PARTICIPANT"
+	RoleNonParticipant Role = "NON-PARTICIPANT"
+)
+
+// Attendee represents a calendar user associated with a scheduling
+// component's ATTENDEE property, as defined in RFC 5545 section 3.8.4.1.
+type Attendee struct {
+	*Prop
+}
+
+// Attendees extracts the list of attendees associated with the event.
+func (e *Event) Attendees() []Attendee {
+	l := make([]Attendee, 0, len(e.Props[PropAttendee]))
+	for i := range e.Props[PropAttendee] {
+		l = append(l, Attendee{&e.Props[PropAttendee][i]})
+	}
+	return l
+}
+
+// CommonName returns the attendee's display name.
+func (a *Attendee) CommonName() string {
+	return a.Params.Get(ParamCommonName)
+}
+
+// CUType returns the attendee's calendar user type, defaulting to
+// CUTypeIndividual when unset and validating it against the known types.
+func (a *Attendee) CUType() (CalendarUserType, error) {
+	switch t := CalendarUserType(a.Params.Get(ParamCalendarUserType)); t {
+	case "":
+		return CUTypeIndividual, nil
+	case CUTypeIndividual, CUTypeGroup, CUTypeResource, CUTypeRoom, CUTypeUnknown:
+		return t, nil
+	default:
+		return "", fmt.Errorf("ical: invalid CUTYPE: %q", t)
+	}
+}
+
+// ParticipationStatus returns the attendee's participation status,
+// defaulting to PartStatNeedsAction when unset and validating it against
+// the known statuses.
+func (a *Attendee) ParticipationStatus() (ParticipationStatus, error) {
+	switch s := ParticipationStatus(a.Params.Get(ParamParticipationStatus)); s {
+	case "":
+		return PartStatNeedsAction, nil
+	case PartStatNeedsAction, PartStatAccepted, PartStatDeclined, PartStatTentative, PartStatDelegated:
+		return s, nil
+	default:
+		return "", fmt.Errorf("ical: invalid PARTSTAT: %q", s)
+	}
+}
+
+// Role returns the attendee's participation role, defaulting to
+// RoleReqParticipant when unset and validating it against the known
+// roles.
+func (a *Attendee) Role() (Role, error) {
+	switch r := Role(a.Params.Get(ParamRole)); r {
+	case "":
+		return RoleReqParticipant, nil
+	case RoleChair, RoleReqParticipant, RoleOptParticipant, RoleNonParticipant:
+		return r, nil
+	default:
+		return "", fmt.Errorf("ical: invalid ROLE: %q", r)
+	}
+}
+
+// RSVP reports whether the organizer expects a reply from the attendee.
+func (a *Attendee) RSVP() bool {
+	return a.Params.Get(ParamRSVP) == "TRUE"
+}
+
+// DelegatedFrom returns the calendar addresses that delegated to this
+// attendee.
+func (a *Attendee) DelegatedFrom() []string {
+	return a.Params[ParamDelegatedFrom]
+}
+
+// DelegatedTo returns the calendar addresses this attendee delegated to.
+func (a *Attendee) DelegatedTo() []string {
+	return a.Params[ParamDelegatedTo]
+}
+
+// Member returns the group(s) the attendee is a member of.
+func (a *Attendee) Member() []string {
+	return a.Params[ParamMember]
+}
+
+// SentBy returns the calendar address of the entity acting on behalf of
+// the attendee.
+func (a *Attendee) SentBy() string {
+	return a.Params.Get(ParamSentBy)
+}
+
+// Dir returns a URI reference to additional information about the
+// attendee.
+func (a *Attendee) Dir() string {
+	return a.Params.Get(ParamDir)
+}
@@ -0,0 +1,39 @@
+package ical
+
+import "testing"
+
+func newTestAttendee(params Params) *Attendee {
+	prop := NewProp(PropAttendee)
+	prop.Params = params
+	return &Attendee{prop}
+}
+
+func TestAttendeeCUTypeDefaultsAndValidates(t *testing.T) {
+	if cu, err := newTestAttendee(nil).CUType(); err != nil || cu != CUTypeIndividual {
+		t.Fatalf("CUType() = %v, %v, want %v, nil", cu, err, CUTypeIndividual)
+	}
+	if cu, err := newTestAttendee(Params{ParamCalendarUserType: {"ROOM"}}).CUType(); err != nil || cu != CUTypeRoom {
+		t.Fatalf("CUType() = %v, %v, want %v, nil", cu, err, CUTypeRoom)
+	}
+	if _, err := newTestAttendee(Params{ParamCalendarUserType: {"BOGUS"}}).CUType(); err == nil {
+		t.Fatal("CUType() with an invalid CUTYPE = nil error, want an error")
+	}
+}
+
+func TestAttendeeParticipationStatusDefaultsAndValidates(t *testing.T) {
+	if s, err := newTestAttendee(nil).ParticipationStatus(); err != nil || s != PartStatNeedsAction {
+		t.Fatalf("ParticipationStatus() = %v, %v, want %v, nil", s, err, PartStatNeedsAction)
+	}
+	if _, err := newTestAttendee(Params{ParamParticipationStatus: {"MAYBE"}}).ParticipationStatus(); err == nil {
+		t.Fatal("ParticipationStatus() with an invalid PARTSTAT = nil error, want an error")
+	}
+}
+
+func TestAttendeeRoleDefaultsAndValidates(t *testing.T) {
+	if r, err := newTestAttendee(nil).Role(); err != nil || r != RoleReqParticipant {
+		t.Fatalf("Role() = %v, %v, want %v, nil", r, err, RoleReqParticipant)
+	}
+	if _, err := newTestAttendee(Params{ParamRole: {"BOSS"}}).Role(); err == nil {
+		t.Fatal("Role() with an invalid ROLE = nil error, want an error")
+	}
+}
@@ -0,0 +1,242 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Decoder parses an iCalendar document, as defined in RFC 5545.
+//
+// By default, the decoder is lenient: unknown components and properties are
+// preserved as opaque data rather than rejected. Set Strict to reject them
+// instead.
+type Decoder struct {
+	Strict bool
+
+	r    *bufio.Reader
+	done bool
+}
+
+// NewDecoder creates a new decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// Decode parses a whole iCalendar document, expecting a single top-level
+// VCALENDAR component.
+func (dec *Decoder) Decode() (*Calendar, error) {
+	comp, err := dec.DecodeComponent()
+	if err != nil {
+		return nil, err
+	}
+	if comp.Name != CompCalendar {
+		return nil, fmt.Errorf("ical: expected top-level %q component, got %q", CompCalendar, comp.Name)
+	}
+
+	if _, err := dec.DecodeComponent(); err != io.EOF {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ical: multiple top-level components")
+	}
+
+	return &Calendar{comp}, nil
+}
+
+// DecodeComponent reads a single top-level component, returning io.EOF once
+// the stream is exhausted. It can be called repeatedly to stream large
+// feeds without buffering the whole document in memory.
+func (dec *Decoder) DecodeComponent() (*Component, error) {
+	if dec.done {
+		return nil, io.EOF
+	}
+
+	line, err := dec.readContentLine()
+	if err == io.EOF {
+		dec.done = true
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, err
+	}
+
+	if line.name != "BEGIN" {
+		return nil, fmt.Errorf("ical: expected BEGIN, got %q", line.name)
+	}
+	return dec.decodeComponent(line.value)
+}
+
+func (dec *Decoder) decodeComponent(name string) (*Component, error) {
+	if dec.Strict {
+		switch strings.ToUpper(name) {
+		case CompCalendar, CompEvent, CompToDo, CompJournal, CompFreeBusy, CompTimezone, CompTimezoneStandard, CompTimezoneDaylight, CompAlarm:
+		default:
+			if !strings.HasPrefix(strings.ToUpper(name), "X-") {
+				return nil, fmt.Errorf("ical: unknown component %q", name)
+			}
+		}
+	}
+
+	comp := NewComponent(name)
+
+	for {
+		line, err := dec.readContentLine()
+		if err == io.EOF {
+			return nil, fmt.Errorf("ical: unexpected EOF in component %q", comp.Name)
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch line.name {
+		case "BEGIN":
+			child, err := dec.decodeComponent(line.value)
+			if err != nil {
+				return nil, err
+			}
+			comp.Children = append(comp.Children, child)
+		case "END":
+			if !strings.EqualFold(line.value, comp.Name) {
+				return nil, fmt.Errorf("ical: expected END:%v, got END:%v", comp.Name, line.value)
+			}
+			return comp, nil
+		default:
+			prop := &Prop{Name: line.name, Params: line.params, Value: line.value}
+			if dec.Strict {
+				if _, ok := defaultValueTypes[prop.Name]; !ok && !strings.HasPrefix(prop.Name, "X-") {
+					return nil, fmt.Errorf("ical: unknown property %q", prop.Name)
+				}
+			}
+			comp.Props.Add(prop)
+		}
+	}
+}
+
+type contentLine struct {
+	name   string
+	params Params
+	value  string
+}
+
+// readContentLine reads and unfolds a single contentline, as defined in
+// RFC 5545 section 3.1.
+func (dec *Decoder) readContentLine() (*contentLine, error) {
+	raw, err := dec.readUnfoldedLine()
+	if err != nil {
+		return nil, err
+	}
+	return parseContentLine(raw)
+}
+
+func (dec *Decoder) readUnfoldedLine() (string, error) {
+	var sb strings.Builder
+
+	line, err := dec.r.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	sb.WriteString(trimEOL(line))
+
+	for {
+		b, err := dec.r.Peek(1)
+		if err != nil || (b[0] != ' ' && b[0] != '\t') {
+			break
+		}
+		dec.r.ReadByte()
+
+		cont, err := dec.r.ReadString('\n')
+		sb.WriteString(trimEOL(cont))
+		if err != nil {
+			break
+		}
+	}
+
+	if sb.Len() == 0 {
+		return "", io.EOF
+	}
+	return sb.String(), nil
+}
+
+func trimEOL(s string) string {
+	s = strings.TrimSuffix(s, "\n")
+	s = strings.TrimSuffix(s, "\r")
+	return s
+}
+
+// parseContentLine splits a single unfolded contentline into its name,
+// parameters and value, as defined in RFC 5545 section 3.1.
+func parseContentLine(s string) (*contentLine, error) {
+	name, rest, params, err := parseName(s)
+	if err != nil {
+		return nil, err
+	}
+
+	for len(rest) > 0 && rest[0] == ';' {
+		var paramName, paramValues string
+		paramName, paramValues, rest, err = parseParam(rest[1:])
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range splitParamValues(paramValues) {
+			params.Add(paramName, v)
+		}
+	}
+
+	if len(rest) == 0 || rest[0] != ':' {
+		return nil, fmt.Errorf("ical: malformed contentline: expected ':'")
+	}
+
+	return &contentLine{name: name, params: params, value: rest[1:]}, nil
+}
+
+func parseName(s string) (name, rest string, params Params, err error) {
+	i := strings.IndexAny(s, ";:")
+	if i < 0 {
+		return "", "", nil, fmt.Errorf("ical: malformed contentline: missing ':'")
+	}
+	return strings.ToUpper(s[:i]), s[i:], make(Params), nil
+}
+
+func parseParam(s string) (name, values, rest string, err error) {
+	i := strings.IndexByte(s, '=')
+	if i < 0 {
+		return "", "", "", fmt.Errorf("ical: malformed parameter: missing '='")
+	}
+	name = strings.ToUpper(s[:i])
+	s = s[i+1:]
+
+	// Find the end of the parameter value list: the next unquoted ';' or
+	// ':'.
+	inQuotes := false
+	for j := 0; j < len(s); j++ {
+		switch s[j] {
+		case '"':
+			inQuotes = !inQuotes
+		case ';', ':':
+			if !inQuotes {
+				return name, s[:j], s[j:], nil
+			}
+		}
+	}
+	return name, s, "", nil
+}
+
+func splitParamValues(s string) []string {
+	var out []string
+	var sb strings.Builder
+	inQuotes := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == ',' && !inQuotes:
+			out = append(out, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	out = append(out, sb.String())
+	return out
+}
@@ -0,0 +1,138 @@
+package ical
+
+import (
+	"io"
+	"sort"
+	"strings"
+)
+
+// foldLineLength is the maximum number of octets per line before folding,
+// as defined in RFC 5545 section 3.1.
+const foldLineLength = 75
+
+// Encoder writes an iCalendar document, as defined in RFC 5545.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder creates a new encoder writing to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes a whole calendar, inserting PRODID and VERSION properties
+// on the VCALENDAR component if they're missing.
+func (enc *Encoder) Encode(cal *Calendar) error {
+	if cal.Props.Get(PropProductID) == nil {
+		cal.Props.SetText(PropProductID, "-//emersion//go-ical//EN")
+	}
+	if cal.Props.Get(PropVersion) == nil {
+		cal.Props.SetText(PropVersion, "2.0")
+	}
+	return enc.EncodeComponent(cal.Component)
+}
+
+// EncodeComponent writes a single component and its children.
+func (enc *Encoder) EncodeComponent(comp *Component) error {
+	if err := enc.writeContentLine(&contentLine{name: "BEGIN", value: comp.Name}); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(comp.Props))
+	for name := range comp.Props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, prop := range comp.Props[name] {
+			p := prop
+			if err := enc.writeContentLine(&contentLine{name: p.Name, params: p.Params, value: p.Value}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, child := range comp.Children {
+		if err := enc.EncodeComponent(child); err != nil {
+			return err
+		}
+	}
+
+	return enc.writeContentLine(&contentLine{name: "END", value: comp.Name})
+}
+
+func (enc *Encoder) writeContentLine(line *contentLine) error {
+	var sb strings.Builder
+	sb.WriteString(line.name)
+
+	names := make([]string, 0, len(line.params))
+	for name := range line.params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		sb.WriteByte(';')
+		sb.WriteString(name)
+		sb.WriteByte('=')
+		for i, v := range line.params[name] {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(quoteParamValue(v))
+		}
+	}
+
+	sb.WriteByte(':')
+	sb.WriteString(line.value)
+
+	return enc.writeFolded(sb.String())
+}
+
+func quoteParamValue(v string) string {
+	if strings.ContainsAny(v, ":;,") {
+		return `"` + v + `"`
+	}
+	return v
+}
+
+// writeFolded writes s as one or more folded lines, each no more than
+// foldLineLength octets, splitting only on UTF-8 boundaries.
+func (enc *Encoder) writeFolded(s string) error {
+	first := true
+	for {
+		limit := foldLineLength
+		if !first {
+			limit-- // leading space on continuation lines
+		}
+
+		n := limit
+		if n > len(s) {
+			n = len(s)
+		}
+		// Don't split a UTF-8 sequence in half.
+		for n > 0 && n < len(s) && isUTF8Continuation(s[n]) {
+			n--
+		}
+
+		if !first {
+			if _, err := io.WriteString(enc.w, " "); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(enc.w, s[:n]+"\r\n"); err != nil {
+			return err
+		}
+
+		s = s[n:]
+		first = false
+		if len(s) == 0 {
+			return nil
+		}
+	}
+}
+
+func isUTF8Continuation(b byte) bool {
+	return b&0xC0 == 0x80
+}
@@ -0,0 +1,255 @@
+package ical
+
+import (
+	"strings"
+	"time"
+)
+
+// Collations supported by TextMatch, as defined in RFC 4791 section 7.5.1.
+const (
+	CollationASCIICaseMap = "i;ascii-casemap"
+	CollationOctet        = "i;octet"
+)
+
+// TextMatch is a text matching condition, as defined in RFC 4791
+// section 9.7.5.
+type TextMatch struct {
+	Text      string
+	Collation string // defaults to CollationASCIICaseMap
+	Negate    bool
+}
+
+// Match reports whether s satisfies the text match condition.
+func (m *TextMatch) Match(s string) bool {
+	var ok bool
+	if m.Collation == CollationOctet {
+		ok = strings.Contains(s, m.Text)
+	} else {
+		ok = strings.Contains(strings.ToLower(s), strings.ToLower(m.Text))
+	}
+	if m.Negate {
+		ok = !ok
+	}
+	return ok
+}
+
+// TimeRange is an inclusive-exclusive time range, as defined in RFC 4791
+// section 9.9.
+type TimeRange struct {
+	Start time.Time // zero means unbounded
+	End   time.Time // zero means unbounded
+}
+
+// overlaps reports whether [start, end) overlaps the time range.
+func (tr *TimeRange) overlaps(start, end time.Time) bool {
+	if !tr.Start.IsZero() && !end.After(tr.Start) {
+		return false
+	}
+	if !tr.End.IsZero() && !start.Before(tr.End) {
+		return false
+	}
+	return true
+}
+
+// ParamFilter is a filter on a property parameter, as defined in RFC 4791
+// section 9.7.3.
+type ParamFilter struct {
+	Name         string
+	IsNotDefined bool
+	TextMatch    *TextMatch
+}
+
+func (f *ParamFilter) match(params Params) bool {
+	values, defined := params[strings.ToUpper(f.Name)]
+	if f.IsNotDefined {
+		return !defined
+	}
+	if !defined {
+		return false
+	}
+	if f.TextMatch == nil {
+		return true
+	}
+	for _, v := range values {
+		if f.TextMatch.Match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// PropFilter is a filter on a property, as defined in RFC 4791 section 9.7.2.
+type PropFilter struct {
+	Name         string
+	IsNotDefined bool
+	TimeRange    *TimeRange
+	TextMatch    *TextMatch
+	ParamFilters []ParamFilter
+}
+
+func (f *PropFilter) match(comp *Component, loc *time.Location) bool {
+	props := comp.Props[strings.ToUpper(f.Name)]
+	if f.IsNotDefined {
+		return len(props) == 0
+	}
+	if len(props) == 0 {
+		return false
+	}
+
+	for _, prop := range props {
+		if f.TextMatch != nil && !f.TextMatch.Match(prop.Value) {
+			continue
+		}
+		if f.TimeRange != nil {
+			t, err := prop.DateTime(loc)
+			if err != nil || !f.TimeRange.overlaps(t, t) {
+				continue
+			}
+		}
+
+		matchesParams := true
+		for i := range f.ParamFilters {
+			if !f.ParamFilters[i].match(prop.Params) {
+				matchesParams = false
+				break
+			}
+		}
+		if matchesParams {
+			return true
+		}
+	}
+	return false
+}
+
+// CompFilter is a filter on a component, as defined in RFC 4791
+// section 9.7.1.
+type CompFilter struct {
+	Name         string
+	IsNotDefined bool
+	TimeRange    *TimeRange
+	PropFilters  []PropFilter
+	CompFilters  []CompFilter
+}
+
+// Match reports whether the calendar matches f. As with a CalDAV top-level
+// comp-filter, f.Name must name the VCALENDAR component itself; f's
+// PropFilters and CompFilters are matched against the calendar's own
+// properties and children. f.TimeRange and f.IsNotDefined are not consulted
+// at this level.
+func (cal *Calendar) Match(f CompFilter) bool {
+	if !strings.EqualFold(f.Name, cal.Name) {
+		return false
+	}
+	return matchCompChildren(cal.Component, f, nil)
+}
+
+func matchComp(comp *Component, f CompFilter, loc *time.Location) bool {
+	if !strings.EqualFold(comp.Name, f.Name) {
+		return false
+	}
+	if f.TimeRange != nil && !matchTimeRange(comp, f.TimeRange, loc) {
+		return false
+	}
+	return matchCompChildren(comp, f, loc)
+}
+
+// matchTimeRange reports whether comp overlaps tr. For a VEVENT with an
+// RRULE, when tr is bounded on both sides, the RRULE is expanded within the
+// range and each occurrence is tested rather than only the first one. Other
+// components, and unbounded ranges, test only the component's own span.
+func matchTimeRange(comp *Component, tr *TimeRange, loc *time.Location) bool {
+	if comp.Name == CompEvent && comp.Props.Get(PropRecurrenceRule) != nil && !tr.Start.IsZero() && !tr.End.IsZero() {
+		e := Event{comp}
+		instances, err := e.Expand(loc, tr.Start, tr.End, nil)
+		if err == nil {
+			for _, inst := range instances {
+				start, end, err := effectiveTimeRange(inst.Component, loc)
+				if err == nil && tr.overlaps(start, end) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	start, end, err := effectiveTimeRange(comp, loc)
+	if err != nil {
+		return false
+	}
+	return tr.overlaps(start, end)
+}
+
+func matchCompChildren(comp *Component, f CompFilter, loc *time.Location) bool {
+	for i := range f.PropFilters {
+		if !f.PropFilters[i].match(comp, loc) {
+			return false
+		}
+	}
+	for i := range f.CompFilters {
+		cf := f.CompFilters[i]
+		if cf.IsNotDefined {
+			found := false
+			for _, child := range comp.Children {
+				if strings.EqualFold(child.Name, cf.Name) {
+					found = true
+					break
+				}
+			}
+			if found {
+				return false
+			}
+			continue
+		}
+
+		matched := false
+		for _, child := range comp.Children {
+			if matchComp(child, cf, loc) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// effectiveTimeRange computes the [DTSTART, DTEND) span of a scheduling
+// component, as used by TimeRange filters, as defined in RFC 4791
+// section 9.9.
+func effectiveTimeRange(comp *Component, loc *time.Location) (start, end time.Time, err error) {
+	start, err = comp.Props.DateTime(PropDateTimeStart, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	switch comp.Name {
+	case CompEvent:
+		end, err = (&Event{comp}).DateTimeEnd(loc)
+	case CompToDo:
+		if due := comp.Props.Get(PropDue); due != nil {
+			end, err = due.DateTime(loc)
+		} else {
+			end = start
+		}
+	case CompJournal:
+		end = start
+	case CompFreeBusy:
+		end, err = comp.Props.DateTime(PropDateTimeEnd, loc)
+	default:
+		end = start
+	}
+	return start, end, err
+}
+
+// Filter returns the calendars among cals that match f.
+func Filter(cals []*Calendar, f CompFilter) []*Calendar {
+	var out []*Calendar
+	for _, cal := range cals {
+		if cal.Match(f) {
+			out = append(out, cal)
+		}
+	}
+	return out
+}
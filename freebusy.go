@@ -0,0 +1,43 @@
+package ical
+
+import "time"
+
+// FreeBusy represents a collection of time ranges, as defined in RFC 5545
+// section 3.6.4.
+type FreeBusy struct {
+	*Component
+}
+
+// NewFreeBusy creates a new free/busy component.
+func NewFreeBusy() *FreeBusy {
+	return &FreeBusy{NewComponent(CompFreeBusy)}
+}
+
+// FreeBusies extracts the list of free/busy components contained in the
+// calendar.
+func (cal *Calendar) FreeBusies() []FreeBusy {
+	l := make([]FreeBusy, 0, len(cal.Children))
+	for _, child := range cal.Children {
+		if child.Name == CompFreeBusy {
+			l = append(l, FreeBusy{child})
+		}
+	}
+	return l
+}
+
+// DateTimeStart returns the start of the overall period covered by the
+// free/busy component.
+func (fb *FreeBusy) DateTimeStart(loc *time.Location) (time.Time, error) {
+	return fb.Props.DateTime(PropDateTimeStart, loc)
+}
+
+// DateTimeEnd returns the end of the overall period covered by the
+// free/busy component.
+func (fb *FreeBusy) DateTimeEnd(loc *time.Location) (time.Time, error) {
+	return fb.Props.DateTime(PropDateTimeEnd, loc)
+}
+
+// FreeBusy returns the busy time periods listed in the FREEBUSY property.
+func (fb *FreeBusy) FreeBusy() ([]Period, error) {
+	return fb.Props.PeriodList(PropFreeBusy)
+}
@@ -6,6 +6,7 @@ package ical
 import (
 	"encoding/base64"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -327,7 +328,248 @@ func (prop *Prop) SetText(text string) {
 	prop.SetTextList([]string{text})
 }
 
-// TODO: Period, RecurrenceRule, Time, URI, UTCOffset
+// UTCOffset parses the property value as a signed offset from UTC, in the
+// "±HHMM[SS]" form.
+func (prop *Prop) UTCOffset() (time.Duration, error) {
+	if err := prop.expectValueType(ValueUTCOffset); err != nil {
+		return 0, err
+	}
+
+	s := prop.Value
+	if len(s) == 0 || (s[0] != '+' && s[0] != '-') {
+		return 0, fmt.Errorf("ical: invalid UTC offset: %q", s)
+	}
+	neg := s[0] == '-'
+	s = s[1:]
+
+	if len(s) != 4 && len(s) != 6 {
+		return 0, fmt.Errorf("ical: invalid UTC offset: %q", prop.Value)
+	}
+
+	hours, err := strconv.Atoi(s[0:2])
+	if err != nil {
+		return 0, fmt.Errorf("ical: invalid UTC offset: %q", prop.Value)
+	}
+	minutes, err := strconv.Atoi(s[2:4])
+	if err != nil {
+		return 0, fmt.Errorf("ical: invalid UTC offset: %q", prop.Value)
+	}
+	var seconds int
+	if len(s) == 6 {
+		seconds, err = strconv.Atoi(s[4:6])
+		if err != nil {
+			return 0, fmt.Errorf("ical: invalid UTC offset: %q", prop.Value)
+		}
+	}
+
+	dur := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	if dur >= 24*time.Hour {
+		return 0, fmt.Errorf("ical: invalid UTC offset: magnitude too large: %q", prop.Value)
+	}
+	if neg {
+		dur = -dur
+	}
+	return dur, nil
+}
+
+// SetUTCOffset sets the property value to dur, formatted as "±HHMM[SS]".
+func (prop *Prop) SetUTCOffset(dur time.Duration) {
+	prop.SetValueType(ValueUTCOffset)
+
+	sign := "+"
+	if dur < 0 {
+		sign = "-"
+		dur = -dur
+	}
+
+	hours := int(dur / time.Hour)
+	minutes := int((dur % time.Hour) / time.Minute)
+	seconds := int((dur % time.Minute) / time.Second)
+
+	if seconds != 0 {
+		prop.Value = fmt.Sprintf("%s%02d%02d%02d", sign, hours, minutes, seconds)
+	} else {
+		prop.Value = fmt.Sprintf("%s%02d%02d", sign, hours, minutes)
+	}
+}
+
+func (props Props) UTCOffset(name string) (time.Duration, error) {
+	if prop := props.Get(name); prop != nil {
+		return prop.UTCOffset()
+	}
+	return 0, nil
+}
+
+func (props Props) SetUTCOffset(name string, dur time.Duration) {
+	prop := NewProp(name)
+	prop.SetUTCOffset(dur)
+	props.Set(prop)
+}
+
+// Period is a span of time, as defined in RFC 5545 section 3.3.9, expressed
+// either as a start/end pair or as a start plus a duration.
+type Period struct {
+	Start       time.Time
+	End         time.Time     // zero if HasDuration is set
+	Duration    time.Duration // zero if HasDuration is unset
+	HasDuration bool
+}
+
+// Period parses the property value as a single PERIOD, in either the
+// "start/end" or "start/duration" form.
+func (prop *Prop) Period() (Period, error) {
+	if err := prop.expectValueType(ValuePeriod); err != nil {
+		return Period{}, err
+	}
+
+	parts := strings.SplitN(prop.Value, "/", 2)
+	if len(parts) != 2 {
+		return Period{}, fmt.Errorf("ical: malformed period: %q", prop.Value)
+	}
+
+	start, err := time.ParseInLocation("20060102T150405Z", parts[0], time.UTC)
+	if err != nil {
+		return Period{}, fmt.Errorf("ical: malformed period start: %q", parts[0])
+	}
+
+	if strings.HasPrefix(parts[1], "P") || strings.HasPrefix(parts[1], "-P") || strings.HasPrefix(parts[1], "+P") {
+		p := durationParser{strings.ToUpper(parts[1])}
+		dur, err := p.parseDuration()
+		if err != nil {
+			return Period{}, fmt.Errorf("ical: malformed period duration: %v", err)
+		}
+		return Period{Start: start, Duration: dur, HasDuration: true}, nil
+	}
+
+	end, err := time.ParseInLocation("20060102T150405Z", parts[1], time.UTC)
+	if err != nil {
+		return Period{}, fmt.Errorf("ical: malformed period end: %q", parts[1])
+	}
+	return Period{Start: start, End: end}, nil
+}
+
+// SetPeriod sets the property value to p, in the "start/end" form, or
+// "start/duration" when p.HasDuration is set.
+func (prop *Prop) SetPeriod(p Period) {
+	prop.SetValueType(ValuePeriod)
+
+	start := p.Start.UTC().Format("20060102T150405Z")
+	if p.HasDuration {
+		prop.Value = start + "/" + formatDuration(p.Duration)
+	} else {
+		prop.Value = start + "/" + p.End.UTC().Format("20060102T150405Z")
+	}
+}
+
+func formatDuration(dur time.Duration) string {
+	p := &Prop{Name: PropDuration, Params: make(Params)}
+	p.SetDuration(dur)
+	return p.Value
+}
+
+// PeriodList parses the property value as a comma-separated list of
+// PERIODs, as used by FREEBUSY.
+func (prop *Prop) PeriodList() ([]Period, error) {
+	if err := prop.expectValueType(ValuePeriod); err != nil {
+		return nil, err
+	}
+
+	var out []Period
+	for _, v := range strings.Split(prop.Value, ",") {
+		p := Prop{Name: prop.Name, Params: prop.Params, Value: v}
+		period, err := p.Period()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, period)
+	}
+	return out, nil
+}
+
+func (props Props) Period(name string) (Period, error) {
+	if prop := props.Get(name); prop != nil {
+		return prop.Period()
+	}
+	return Period{}, nil
+}
+
+func (props Props) SetPeriod(name string, p Period) {
+	prop := NewProp(name)
+	prop.SetPeriod(p)
+	props.Set(prop)
+}
+
+func (props Props) PeriodList(name string) ([]Period, error) {
+	if prop := props.Get(name); prop != nil {
+		return prop.PeriodList()
+	}
+	return nil, nil
+}
+
+// URI parses the property value as a URI, as used e.g. by ATTACH and
+// CAL-ADDRESS-typed properties such as ORGANIZER and ATTENDEE.
+func (prop *Prop) URI() (*url.URL, error) {
+	switch t := prop.ValueType(); t {
+	case ValueURI, ValueCalendarAddress:
+		return url.Parse(prop.Value)
+	default:
+		return nil, fmt.Errorf("ical: expected URI or CAL-ADDRESS, got %q", t)
+	}
+}
+
+// SetURI sets the property value to u, typed as URI.
+func (prop *Prop) SetURI(u *url.URL) {
+	prop.SetValueType(ValueURI)
+	prop.Value = u.String()
+}
+
+func (props Props) URI(name string) (*url.URL, error) {
+	if prop := props.Get(name); prop != nil {
+		return prop.URI()
+	}
+	return nil, nil
+}
+
+func (props Props) SetURI(name string, u *url.URL) {
+	prop := NewProp(name)
+	prop.SetURI(u)
+	props.Set(prop)
+}
+
+// Time parses the property value as a TIME, in the "HHMMSS[Z]" form, within
+// loc (ignored if the value is UTC).
+func (prop *Prop) Time(loc *time.Location) (time.Time, error) {
+	if err := prop.expectValueType(ValueTime); err != nil {
+		return time.Time{}, err
+	}
+	if strings.HasSuffix(prop.Value, "Z") {
+		return time.ParseInLocation("150405Z", prop.Value, time.UTC)
+	}
+	return time.ParseInLocation("150405", prop.Value, loc)
+}
+
+// SetTime sets the property value to t, typed as TIME.
+func (prop *Prop) SetTime(t time.Time) {
+	prop.SetValueType(ValueTime)
+	if t.Location() == time.UTC {
+		prop.Value = t.Format("150405Z")
+	} else {
+		prop.Value = t.Format("150405")
+	}
+}
+
+func (props Props) Time(name string, loc *time.Location) (time.Time, error) {
+	if prop := props.Get(name); prop != nil {
+		return prop.Time(loc)
+	}
+	return time.Time{}, nil
+}
+
+func (props Props) SetTime(name string, t time.Time) {
+	prop := NewProp(name)
+	prop.SetTime(t)
+	props.Set(prop)
+}
 
 // Props is a set of component properties.
 type Props map[string][]Prop
@@ -0,0 +1,23 @@
+package ical
+
+// Journal represents a record of an activity, as defined in RFC 5545
+// section 3.6.3.
+type Journal struct {
+	*Component
+}
+
+// NewJournal creates a new journal entry.
+func NewJournal() *Journal {
+	return &Journal{NewComponent(CompJournal)}
+}
+
+// Journals extracts the list of journal entries contained in the calendar.
+func (cal *Calendar) Journals() []Journal {
+	l := make([]Journal, 0, len(cal.Children))
+	for _, child := range cal.Children {
+		if child.Name == CompJournal {
+			l = append(l, Journal{child})
+		}
+	}
+	return l
+}
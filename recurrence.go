@@ -0,0 +1,829 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Freq is the frequency of a recurrence rule, as defined in RFC 5545
+// section 3.3.10.
+type Freq string
+
+// Recurrence frequencies.
+const (
+	FreqSecondly Freq = "SECONDLY"
+	FreqMinutely Freq = "MINUTELY"
+	FreqHourly   Freq = "HOURLY"
+	FreqDaily    Freq = "DAILY"
+	FreqWeekly   Freq = "WEEKLY"
+	FreqMonthly  Freq = "MONTHLY"
+	FreqYearly   Freq = "YEARLY"
+)
+
+// RuleWeekday is a weekday optionally prefixed with an ordinal, as used in
+// the BYDAY part of a recurrence rule (e.g. "2MO" is the second Monday).
+type RuleWeekday struct {
+	Day time.Weekday
+	N   int // zero if no ordinal is set
+}
+
+func (wd RuleWeekday) String() string {
+	var s string
+	if wd.N != 0 {
+		s = strconv.Itoa(wd.N)
+	}
+	return s + weekdayNames[wd.Day]
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+var weekdayValues = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func parseRuleWeekday(s string) (RuleWeekday, error) {
+	// Split the trailing two-letter weekday code from the optional
+	// leading signed ordinal.
+	if len(s) < 2 {
+		return RuleWeekday{}, fmt.Errorf("ical: invalid BYDAY value: %q", s)
+	}
+	dayCode := s[len(s)-2:]
+	day, ok := weekdayValues[dayCode]
+	if !ok {
+		return RuleWeekday{}, fmt.Errorf("ical: invalid BYDAY weekday: %q", dayCode)
+	}
+
+	var n int
+	if rest := s[:len(s)-2]; rest != "" {
+		v, err := strconv.Atoi(rest)
+		if err != nil {
+			return RuleWeekday{}, fmt.Errorf("ical: invalid BYDAY ordinal: %q", rest)
+		}
+		n = v
+	}
+
+	return RuleWeekday{Day: day, N: n}, nil
+}
+
+// RecurrenceRule represents a RRULE value, as defined in RFC 5545
+// section 3.3.10.
+type RecurrenceRule struct {
+	Freq       Freq
+	Until      time.Time // zero if not set
+	Count      int       // zero if not set
+	Interval   int       // zero means 1
+	BySecond   []int
+	ByMinute   []int
+	ByHour     []int
+	ByDay      []RuleWeekday
+	ByMonthDay []int
+	ByYearDay  []int
+	ByWeekNo   []int
+	ByMonth    []int
+	BySetPos   []int
+	WeekStart  time.Weekday // RFC 5545 default is Monday; ParseRecurrenceRule sets this explicitly
+}
+
+// ParseRecurrenceRule parses a RRULE value as defined in RFC 5545
+// section 3.3.10.
+func ParseRecurrenceRule(s string) (*RecurrenceRule, error) {
+	rrule := &RecurrenceRule{WeekStart: time.Monday}
+
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("ical: invalid RRULE part: %q", part)
+		}
+		name, value := strings.ToUpper(kv[0]), kv[1]
+
+		var err error
+		switch name {
+		case "FREQ":
+			rrule.Freq = Freq(strings.ToUpper(value))
+		case "UNTIL":
+			rrule.Until, err = parseRecurUntil(value)
+		case "COUNT":
+			rrule.Count, err = strconv.Atoi(value)
+		case "INTERVAL":
+			rrule.Interval, err = strconv.Atoi(value)
+		case "BYSECOND":
+			rrule.BySecond, err = parseIntList(value)
+		case "BYMINUTE":
+			rrule.ByMinute, err = parseIntList(value)
+		case "BYHOUR":
+			rrule.ByHour, err = parseIntList(value)
+		case "BYDAY":
+			for _, v := range strings.Split(value, ",") {
+				wd, werr := parseRuleWeekday(v)
+				if werr != nil {
+					err = werr
+					break
+				}
+				rrule.ByDay = append(rrule.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			rrule.ByMonthDay, err = parseIntList(value)
+		case "BYYEARDAY":
+			rrule.ByYearDay, err = parseIntList(value)
+		case "BYWEEKNO":
+			rrule.ByWeekNo, err = parseIntList(value)
+		case "BYMONTH":
+			rrule.ByMonth, err = parseIntList(value)
+		case "BYSETPOS":
+			rrule.BySetPos, err = parseIntList(value)
+		case "WKST":
+			wd, ok := weekdayValues[strings.ToUpper(value)]
+			if !ok {
+				err = fmt.Errorf("ical: invalid WKST value: %q", value)
+			} else {
+				rrule.WeekStart = wd
+			}
+		default:
+			// Unknown parts are ignored, per RFC 5545 section 3.3.10.
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ical: invalid RRULE part %q: %v", part, err)
+		}
+	}
+
+	if rrule.Freq == "" {
+		return nil, fmt.Errorf("ical: RRULE is missing FREQ")
+	}
+	if rrule.Count > 0 && !rrule.Until.IsZero() {
+		return nil, fmt.Errorf("ical: RRULE cannot have both COUNT and UNTIL")
+	}
+
+	return rrule, nil
+}
+
+func parseRecurUntil(s string) (time.Time, error) {
+	if strings.HasSuffix(s, "Z") {
+		return time.ParseInLocation("20060102T150405Z", s, time.UTC)
+	}
+	if strings.Contains(s, "T") {
+		return time.ParseInLocation("20060102T150405", s, time.UTC)
+	}
+	return time.ParseInLocation("20060102", s, time.UTC)
+}
+
+func parseIntList(s string) ([]int, error) {
+	parts := strings.Split(s, ",")
+	l := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", p)
+		}
+		l[i] = n
+	}
+	return l, nil
+}
+
+// String serializes the recurrence rule back to its RRULE value form.
+func (rrule *RecurrenceRule) String() string {
+	var sb strings.Builder
+	sb.WriteString("FREQ=")
+	sb.WriteString(string(rrule.Freq))
+
+	if !rrule.Until.IsZero() {
+		sb.WriteString(";UNTIL=")
+		sb.WriteString(rrule.Until.UTC().Format("20060102T150405Z"))
+	}
+	if rrule.Count > 0 {
+		fmt.Fprintf(&sb, ";COUNT=%d", rrule.Count)
+	}
+	if rrule.Interval > 1 {
+		fmt.Fprintf(&sb, ";INTERVAL=%d", rrule.Interval)
+	}
+	writeIntList(&sb, "BYSECOND", rrule.BySecond)
+	writeIntList(&sb, "BYMINUTE", rrule.ByMinute)
+	writeIntList(&sb, "BYHOUR", rrule.ByHour)
+	if len(rrule.ByDay) > 0 {
+		sb.WriteString(";BYDAY=")
+		for i, wd := range rrule.ByDay {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(wd.String())
+		}
+	}
+	writeIntList(&sb, "BYMONTHDAY", rrule.ByMonthDay)
+	writeIntList(&sb, "BYYEARDAY", rrule.ByYearDay)
+	writeIntList(&sb, "BYWEEKNO", rrule.ByWeekNo)
+	writeIntList(&sb, "BYMONTH", rrule.ByMonth)
+	writeIntList(&sb, "BYSETPOS", rrule.BySetPos)
+	if rrule.WeekStart != time.Monday {
+		sb.WriteString(";WKST=")
+		sb.WriteString(weekdayNames[rrule.WeekStart])
+	}
+
+	return sb.String()
+}
+
+func writeIntList(sb *strings.Builder, name string, l []int) {
+	if len(l) == 0 {
+		return
+	}
+	sb.WriteByte(';')
+	sb.WriteString(name)
+	sb.WriteByte('=')
+	for i, n := range l {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.Itoa(n))
+	}
+}
+
+func (prop *Prop) RecurrenceRule() (*RecurrenceRule, error) {
+	if err := prop.expectValueType(ValueRecurrence); err != nil {
+		return nil, err
+	}
+	return ParseRecurrenceRule(prop.Value)
+}
+
+func (prop *Prop) SetRecurrenceRule(rrule *RecurrenceRule) {
+	prop.SetValueType(ValueRecurrence)
+	prop.Value = rrule.String()
+}
+
+func (props Props) RecurrenceRule(name string) (*RecurrenceRule, error) {
+	if prop := props.Get(name); prop != nil {
+		return prop.RecurrenceRule()
+	}
+	return nil, nil
+}
+
+func (props Props) SetRecurrenceRule(name string, rrule *RecurrenceRule) {
+	prop := NewProp(name)
+	prop.SetRecurrenceRule(rrule)
+	props.Set(prop)
+}
+
+// intervalOrOne returns rrule.Interval, defaulting to 1 when unset.
+func (rrule *RecurrenceRule) intervalOrOne() int {
+	if rrule.Interval <= 0 {
+		return 1
+	}
+	return rrule.Interval
+}
+
+// occurrences expands the rule's candidate instances starting at dtstart,
+// up to the "to" bound, honoring COUNT/UNTIL. It does not consult RDATE or
+// EXDATE, which are merged in by Event.Expand.
+func (rrule *RecurrenceRule) occurrences(dtstart time.Time, to time.Time) []time.Time {
+	var out []time.Time
+	interval := rrule.intervalOrOne()
+	count := 0
+
+	switch rrule.Freq {
+	case FreqSecondly, FreqMinutely, FreqHourly:
+		step := timeStepFor(rrule.Freq) * time.Duration(interval)
+		for t := dtstart; ; t = t.Add(step) {
+			if !rrule.Until.IsZero() && t.After(rrule.Until) {
+				break
+			}
+			if t.After(to) {
+				// Time only advances, so every later candidate will also
+				// be past the window: stop regardless of COUNT/UNTIL.
+				break
+			}
+			var period []time.Time
+			if rrule.matchesByFilters(t) {
+				period = []time.Time{t}
+			}
+			if !rrule.emitPeriod(period, dtstart, &out, &count, to) {
+				break
+			}
+		}
+	case FreqDaily:
+		for t := dtstart; ; t = t.AddDate(0, 0, interval) {
+			if !rrule.Until.IsZero() && t.After(rrule.Until) {
+				break
+			}
+			if !rrule.emitPeriod(rrule.dailyCandidates(t), dtstart, &out, &count, to) {
+				break
+			}
+			if t.After(to) {
+				break
+			}
+		}
+	case FreqWeekly:
+		for t := dtstart; ; t = t.AddDate(0, 0, 7*interval) {
+			if !rrule.emitPeriod(rrule.weeklyCandidates(t, dtstart), dtstart, &out, &count, to) {
+				break
+			}
+			if t.After(to) {
+				break
+			}
+		}
+	case FreqMonthly:
+		for t := firstOfMonth(dtstart); ; t = t.AddDate(0, interval, 0) {
+			if !rrule.emitPeriod(rrule.monthlyCandidates(t, dtstart), dtstart, &out, &count, to) {
+				break
+			}
+			if t.After(to) {
+				break
+			}
+		}
+	case FreqYearly:
+		for t := firstOfYear(dtstart); ; t = t.AddDate(interval, 0, 0) {
+			if !rrule.emitPeriod(rrule.yearlyCandidates(t, dtstart), dtstart, &out, &count, to) {
+				break
+			}
+			if t.After(to) {
+				break
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return out
+}
+
+// emitPeriod applies BYSETPOS to a single period's candidate set (e.g. one
+// month's matching days), per RFC 5545's requirement that BYSETPOS select
+// within each period rather than across the whole expansion, then appends
+// whatever remains inside [dtstart, to] ∩ (..., UNTIL] to out. It returns
+// false once COUNT instances have been emitted, so the caller can stop
+// generating further periods.
+func (rrule *RecurrenceRule) emitPeriod(candidates []time.Time, dtstart time.Time, out *[]time.Time, count *int, to time.Time) bool {
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	for _, t := range applyBySetPos(candidates, rrule.BySetPos) {
+		if t.Before(dtstart) {
+			continue
+		}
+		if !rrule.Until.IsZero() && t.After(rrule.Until) {
+			continue
+		}
+		if t.After(to) {
+			continue
+		}
+		*out = append(*out, t)
+		*count++
+		if rrule.Count > 0 && *count >= rrule.Count {
+			return false
+		}
+	}
+	return true
+}
+
+func timeStepFor(freq Freq) time.Duration {
+	switch freq {
+	case FreqSecondly:
+		return time.Second
+	case FreqMinutely:
+		return time.Minute
+	case FreqHourly:
+		return time.Hour
+	default:
+		return 0
+	}
+}
+
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+}
+
+func firstOfYear(t time.Time) time.Time {
+	return time.Date(t.Year(), time.January, 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// dailyCandidates returns day's BY*-matching instants, the period that
+// BYSETPOS selects within for FREQ=DAILY.
+func (rrule *RecurrenceRule) dailyCandidates(day time.Time) []time.Time {
+	var out []time.Time
+	for _, t := range rrule.expandTime(day) {
+		if rrule.matchesByFilters(t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// weeklyCandidates returns every BY*-matching instant across the week
+// (beginning on WKST) that contains weekStart, the period that BYSETPOS
+// selects within for FREQ=WEEKLY.
+func (rrule *RecurrenceRule) weeklyCandidates(weekStart, dtstart time.Time) []time.Time {
+	days := rrule.ByDay
+	if len(days) == 0 {
+		days = []RuleWeekday{{Day: dtstart.Weekday()}}
+	}
+	start := weekStart.AddDate(0, 0, -weekdayOffset(weekStart.Weekday(), rrule.WeekStart))
+	var out []time.Time
+	for _, wd := range days {
+		offset := weekdayOffset(wd.Day, rrule.WeekStart)
+		day := start.AddDate(0, 0, offset)
+		out = append(out, rrule.dailyCandidates(day)...)
+	}
+	return out
+}
+
+func weekdayOffset(wd, wkst time.Weekday) int {
+	return (int(wd) - int(wkst) + 7) % 7
+}
+
+// monthlyCandidates returns every BY*-matching instant across month, the
+// period that BYSETPOS selects within for FREQ=MONTHLY.
+func (rrule *RecurrenceRule) monthlyCandidates(month, dtstart time.Time) []time.Time {
+	var out []time.Time
+	for _, day := range rrule.monthDays(month, dtstart) {
+		out = append(out, rrule.dailyCandidates(day)...)
+	}
+	return out
+}
+
+func (rrule *RecurrenceRule) monthDays(month, dtstart time.Time) []time.Time {
+	first := firstOfMonth(month)
+	last := first.AddDate(0, 1, -1).Day()
+
+	var candidates []int
+	if len(rrule.ByMonthDay) > 0 {
+		for _, n := range rrule.ByMonthDay {
+			d := n
+			if d < 0 {
+				d = last + d + 1
+			}
+			if d >= 1 && d <= last {
+				candidates = append(candidates, d)
+			}
+		}
+	} else if len(rrule.ByDay) > 0 {
+		for day := 1; day <= last; day++ {
+			t := time.Date(first.Year(), first.Month(), day, first.Hour(), first.Minute(), first.Second(), 0, first.Location())
+			for _, wd := range rrule.ByDay {
+				if wd.Day == t.Weekday() && (wd.N == 0 || nthWeekdayOfMonth(t, last) == wd.N || nthWeekdayOfMonthNeg(t, last) == wd.N) {
+					candidates = append(candidates, day)
+				}
+			}
+		}
+	} else if dtstart.Day() <= last {
+		// Plain FREQ=MONTHLY recurs on DTSTART's day-of-month; months
+		// shorter than that day (e.g. Feb for a 31st) have no occurrence.
+		candidates = append(candidates, dtstart.Day())
+	}
+
+	out := make([]time.Time, 0, len(candidates))
+	for _, d := range candidates {
+		out = append(out, time.Date(first.Year(), first.Month(), d, first.Hour(), first.Minute(), first.Second(), 0, first.Location()))
+	}
+	return out
+}
+
+func nthWeekdayOfMonth(t time.Time, lastDay int) int {
+	return (t.Day()-1)/7 + 1
+}
+
+func nthWeekdayOfMonthNeg(t time.Time, lastDay int) int {
+	return -((lastDay - t.Day()) / 7) - 1
+}
+
+// yearlyCandidates returns every BY*-matching instant across year, the
+// period that BYSETPOS selects within for FREQ=YEARLY.
+func (rrule *RecurrenceRule) yearlyCandidates(year, dtstart time.Time) []time.Time {
+	var days []time.Time
+	switch {
+	case len(rrule.ByYearDay) > 0:
+		last := firstOfYear(year).AddDate(1, 0, -1).YearDay()
+		for _, n := range rrule.ByYearDay {
+			d := n
+			if d < 0 {
+				d = last + d + 1
+			}
+			if d >= 1 && d <= last {
+				days = append(days, firstOfYear(year).AddDate(0, 0, d-1))
+			}
+		}
+	case len(rrule.ByMonth) > 0:
+		for _, m := range rrule.ByMonth {
+			month := time.Date(year.Year(), time.Month(m), 1, year.Hour(), year.Minute(), year.Second(), 0, year.Location())
+			days = append(days, rrule.monthDays(month, dtstart)...)
+		}
+	default:
+		// Plain FREQ=YEARLY recurs on DTSTART's month/day; skip years that
+		// don't have that day (e.g. Feb 29 outside a leap year) rather
+		// than letting it roll over into the next month.
+		if dtstart.Day() <= daysInMonth(year.Year(), dtstart.Month()) {
+			days = append(days, time.Date(year.Year(), dtstart.Month(), dtstart.Day(), year.Hour(), year.Minute(), year.Second(), 0, year.Location()))
+		}
+	}
+
+	var out []time.Time
+	for _, day := range days {
+		out = append(out, rrule.dailyCandidates(day)...)
+	}
+	return out
+}
+
+// expandTime applies BYHOUR/BYMINUTE/BYSECOND to a day, returning the set
+// of candidate instants within that day.
+func (rrule *RecurrenceRule) expandTime(day time.Time) []time.Time {
+	hours := rrule.ByHour
+	if len(hours) == 0 {
+		hours = []int{day.Hour()}
+	}
+	minutes := rrule.ByMinute
+	if len(minutes) == 0 {
+		minutes = []int{day.Minute()}
+	}
+	seconds := rrule.BySecond
+	if len(seconds) == 0 {
+		seconds = []int{day.Second()}
+	}
+
+	var out []time.Time
+	for _, h := range hours {
+		for _, m := range minutes {
+			for _, s := range seconds {
+				out = append(out, time.Date(day.Year(), day.Month(), day.Day(), h, m, s, 0, day.Location()))
+			}
+		}
+	}
+	return out
+}
+
+// matchesByFilters applies the BY* parts that the *Candidates generators
+// haven't already folded into candidate generation: BYMONTH for every
+// frequency but YEARLY, BYWEEKNO for every frequency, plus
+// BYDAY/BYMONTHDAY/BYYEARDAY for the
+// DAILY and sub-daily frequencies, whose candidate generation is a plain
+// time step with no day-of-week/month/year selection of its own.
+func (rrule *RecurrenceRule) matchesByFilters(t time.Time) bool {
+	if len(rrule.ByMonth) > 0 && rrule.Freq != FreqYearly {
+		ok := false
+		for _, m := range rrule.ByMonth {
+			if time.Month(m) == t.Month() {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(rrule.ByWeekNo) > 0 {
+		_, week := t.ISOWeek()
+		ok := false
+		for _, w := range rrule.ByWeekNo {
+			if w == week {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	switch rrule.Freq {
+	case FreqDaily, FreqHourly, FreqMinutely, FreqSecondly:
+		if len(rrule.ByMonthDay) > 0 && !matchesByMonthDay(rrule.ByMonthDay, t) {
+			return false
+		}
+		if len(rrule.ByYearDay) > 0 && !matchesByYearDay(rrule.ByYearDay, t) {
+			return false
+		}
+		if len(rrule.ByDay) > 0 && !matchesByDay(rrule.ByDay, t) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesByDay(days []RuleWeekday, t time.Time) bool {
+	for _, wd := range days {
+		if wd.Day == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesByMonthDay(monthDays []int, t time.Time) bool {
+	last := daysInMonth(t.Year(), t.Month())
+	for _, n := range monthDays {
+		d := n
+		if d < 0 {
+			d = last + d + 1
+		}
+		if d == t.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesByYearDay(yearDays []int, t time.Time) bool {
+	last := firstOfYear(t).AddDate(1, 0, -1).YearDay()
+	yd := t.YearDay()
+	for _, n := range yearDays {
+		d := n
+		if d < 0 {
+			d = last + d + 1
+		}
+		if d == yd {
+			return true
+		}
+	}
+	return false
+}
+
+func applyBySetPos(instances []time.Time, bySetPos []int) []time.Time {
+	if len(bySetPos) == 0 {
+		return instances
+	}
+	var out []time.Time
+	for _, pos := range bySetPos {
+		idx := pos
+		if idx < 0 {
+			idx = len(instances) + idx + 1
+		}
+		if idx >= 1 && idx <= len(instances) {
+			out = append(out, instances[idx-1])
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return out
+}
+
+// Expand returns the concrete occurrences of the event that start no later
+// than to and either start at or after from or are still in progress at
+// from, combining DTSTART with RRULE, RDATE and EXDATE. siblings
+// provides the other VEVENTs sharing this event's UID, used to resolve
+// RECURRENCE-ID overrides; it may be nil.
+func (e *Event) Expand(loc *time.Location, from, to time.Time, siblings []Event) ([]Event, error) {
+	dtstart, err := e.DateTimeStart(loc)
+	if err != nil {
+		return nil, err
+	}
+	dtend, err := e.DateTimeEnd(loc)
+	if err != nil {
+		return nil, err
+	}
+	duration := dtend.Sub(dtstart)
+
+	instants := map[int64]time.Time{}
+	addInstant := func(t time.Time) {
+		instants[t.UTC().Unix()] = t
+	}
+
+	if rrule, err := e.Props.RecurrenceRule(PropRecurrenceRule); err != nil {
+		return nil, err
+	} else if rrule != nil {
+		for _, t := range rrule.occurrences(dtstart, to) {
+			addInstant(t)
+		}
+	} else {
+		addInstant(dtstart)
+	}
+
+	for _, prop := range e.Props[PropRecurrenceDates] {
+		ts, err := parseRDate(&prop, loc)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range ts {
+			addInstant(t)
+		}
+	}
+
+	for _, prop := range e.Props[PropExceptionDates] {
+		ts, err := parseRDate(&prop, loc)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range ts {
+			delete(instants, t.UTC().Unix())
+		}
+	}
+
+	overrides := map[int64]*Event{}
+	for i, sibling := range siblings {
+		rid := sibling.Props.Get(PropRecurrenceID)
+		if rid == nil {
+			continue
+		}
+		t, err := rid.DateTime(loc)
+		if err != nil {
+			return nil, err
+		}
+		overrides[t.UTC().Unix()] = &siblings[i]
+	}
+
+	var out []Event
+	for key, t := range instants {
+		if t.Before(from) && !t.Add(duration).After(from) {
+			continue
+		}
+		if t.After(to) {
+			continue
+		}
+		if override, ok := overrides[key]; ok {
+			out = append(out, *override)
+			continue
+		}
+
+		instance := Event{&Component{
+			Name:     e.Name,
+			Props:    cloneProps(e.Props),
+			Children: e.Children,
+		}}
+		instance.Props.SetDateTime(PropDateTimeStart, t)
+		if e.Props.Get(PropDateTimeEnd) != nil {
+			instance.Props.SetDateTime(PropDateTimeEnd, t.Add(duration))
+		}
+		out = append(out, instance)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		ti, _ := out[i].DateTimeStart(loc)
+		tj, _ := out[j].DateTimeStart(loc)
+		return ti.Before(tj)
+	})
+	return out, nil
+}
+
+// Expand returns the concrete occurrences of every VEVENT in the calendar
+// within from and to, as selected by Event.Expand, resolving RECURRENCE-ID overrides
+// against sibling VEVENTs that share the same UID.
+func (cal *Calendar) Expand(loc *time.Location, from, to time.Time) ([]Event, error) {
+	byUID := map[string][]Event{}
+	for _, e := range cal.Events() {
+		uid, err := e.Props.Text(PropUID)
+		if err != nil {
+			return nil, err
+		}
+		byUID[uid] = append(byUID[uid], e)
+	}
+
+	var out []Event
+	for _, siblings := range byUID {
+		for _, e := range siblings {
+			if e.Props.Get(PropRecurrenceID) != nil {
+				continue
+			}
+			instances, err := e.Expand(loc, from, to, siblings)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, instances...)
+		}
+	}
+	return out, nil
+}
+
+func cloneProps(props Props) Props {
+	out := make(Props, len(props))
+	for name, l := range props {
+		cl := make([]Prop, len(l))
+		copy(cl, l)
+		out[name] = cl
+	}
+	return out
+}
+
+func parseRDate(prop *Prop, loc *time.Location) ([]time.Time, error) {
+	var out []time.Time
+	for _, v := range strings.Split(prop.Value, ",") {
+		if prop.ValueType() == ValuePeriod {
+			start := strings.SplitN(v, "/", 2)[0]
+			t, err := time.ParseInLocation("20060102T150405Z", start, time.UTC)
+			if err != nil {
+				return nil, fmt.Errorf("ical: invalid RDATE period: %q", v)
+			}
+			out = append(out, t)
+			continue
+		}
+		p := Prop{Name: prop.Name, Params: prop.Params, Value: v}
+		t, err := p.DateTime(loc)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
@@ -0,0 +1,133 @@
+package ical
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParseRRule(t *testing.T, s string) *RecurrenceRule {
+	t.Helper()
+	rrule, err := ParseRecurrenceRule(s)
+	if err != nil {
+		t.Fatalf("ParseRecurrenceRule(%q) = _, %v", s, err)
+	}
+	return rrule
+}
+
+func dateUTC(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func occurrenceDates(t *testing.T, rrule *RecurrenceRule, dtstart, to time.Time) []string {
+	t.Helper()
+	var got []string
+	for _, occ := range rrule.occurrences(dtstart, to) {
+		got = append(got, occ.Format("20060102"))
+	}
+	return got
+}
+
+func assertDates(t *testing.T, got []string, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d occurrences %v, want %d %v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("occurrence %d = %v, want %v (all got: %v)", i, got[i], want[i], got)
+		}
+	}
+}
+
+func TestRecurrenceRuleMonthlyDefaultsToDTStartDay(t *testing.T) {
+	rrule := mustParseRRule(t, "FREQ=MONTHLY;COUNT=3")
+	dtstart := dateUTC(2024, 1, 15)
+	got := occurrenceDates(t, rrule, dtstart, dateUTC(2024, 6, 1))
+	assertDates(t, got, []string{"20240115", "20240215", "20240315"})
+}
+
+func TestRecurrenceRuleDailyByDay(t *testing.T) {
+	rrule := mustParseRRule(t, "FREQ=DAILY;BYDAY=MO,WE,FR")
+	dtstart := dateUTC(2024, 1, 1) // a Monday
+	got := occurrenceDates(t, rrule, dtstart, dateUTC(2024, 1, 8))
+	assertDates(t, got, []string{"20240101", "20240103", "20240105", "20240108"})
+}
+
+func TestRecurrenceRuleYearlySkipsNonExistentFeb29(t *testing.T) {
+	rrule := mustParseRRule(t, "FREQ=YEARLY;COUNT=3")
+	dtstart := dateUTC(2024, 2, 29)
+	got := occurrenceDates(t, rrule, dtstart, dateUTC(2033, 1, 1))
+	// 2025, 2026 and 2027 aren't leap years, so Feb 29 doesn't exist; the
+	// next occurrence is the following leap year, not a rollover to Mar 1.
+	assertDates(t, got, []string{"20240229", "20280229", "20320229"})
+}
+
+func TestRecurrenceRuleHourlyUnboundedTerminatesAtWindow(t *testing.T) {
+	rrule := mustParseRRule(t, "FREQ=HOURLY")
+	dtstart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC)
+
+	done := make(chan []time.Time, 1)
+	go func() {
+		done <- rrule.occurrences(dtstart, to)
+	}()
+
+	select {
+	case occs := <-done:
+		if len(occs) != 6 {
+			t.Fatalf("got %d occurrences, want 6", len(occs))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("unbounded FREQ=HOURLY rule did not terminate")
+	}
+}
+
+func TestRecurrenceRuleYearlySkipsNonExistentFeb29RespectsCount(t *testing.T) {
+	rrule := mustParseRRule(t, "FREQ=YEARLY;COUNT=2")
+	dtstart := dateUTC(2024, 2, 29)
+	got := occurrenceDates(t, rrule, dtstart, dateUTC(2100, 1, 1))
+	assertDates(t, got, []string{"20240229", "20280229"})
+}
+
+func TestRecurrenceRuleBySetPosAppliesPerMonth(t *testing.T) {
+	rrule := mustParseRRule(t, "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1")
+	dtstart := dateUTC(2024, 1, 1)
+	got := occurrenceDates(t, rrule, dtstart, dateUTC(2024, 4, 1))
+	// BYSETPOS=-1 selects the last matching weekday of each month, not the
+	// last matching weekday across the whole window.
+	assertDates(t, got, []string{"20240131", "20240229", "20240329"})
+}
+
+func TestRecurrenceRuleBySetPosCountsAfterSelection(t *testing.T) {
+	rrule := mustParseRRule(t, "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1;COUNT=3")
+	dtstart := dateUTC(2024, 1, 1)
+	got := occurrenceDates(t, rrule, dtstart, dateUTC(2024, 12, 1))
+	// COUNT must bound the post-BYSETPOS stream (one per month), not the
+	// pre-selection candidate set (every weekday).
+	assertDates(t, got, []string{"20240101", "20240201", "20240301"})
+}
+
+func TestEventExpandAppliesRRuleAndExdate(t *testing.T) {
+	event := NewEvent()
+	event.Props.SetText(PropUID, "event-1")
+	event.Props.SetDateTime(PropDateTimeStart, dateUTC(2024, 1, 1))
+	event.Props.SetRecurrenceRule(PropRecurrenceRule, mustParseRRule(t, "FREQ=DAILY;COUNT=5"))
+	exdate := NewProp(PropExceptionDates)
+	exdate.SetDateTime(dateUTC(2024, 1, 3))
+	event.Props.Add(exdate)
+
+	instances, err := event.Expand(time.UTC, dateUTC(2024, 1, 1), dateUTC(2024, 1, 10), nil)
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+
+	var got []string
+	for _, inst := range instances {
+		start, err := inst.DateTimeStart(time.UTC)
+		if err != nil {
+			t.Fatalf("DateTimeStart() error = %v", err)
+		}
+		got = append(got, start.Format("20060102"))
+	}
+	assertDates(t, got, []string{"20240101", "20240102", "20240104", "20240105"})
+}
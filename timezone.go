@@ -0,0 +1,309 @@
+package ical
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// TimezoneRegistry resolves TZID parameters to *time.Location values
+// synthesized from a calendar's VTIMEZONE components, falling back to Go's
+// IANA tzdata when a VTIMEZONE isn't present.
+type TimezoneRegistry struct {
+	locations map[string]*time.Location
+}
+
+// NewTimezoneRegistry builds a registry from every VTIMEZONE child of cal.
+func NewTimezoneRegistry(cal *Calendar) (*TimezoneRegistry, error) {
+	reg := &TimezoneRegistry{locations: make(map[string]*time.Location)}
+	for _, child := range cal.Children {
+		if child.Name != CompTimezone {
+			continue
+		}
+		tzid, err := child.Props.Text(PropTimezoneID)
+		if err != nil {
+			return nil, err
+		}
+		loc, err := buildLocation(tzid, child)
+		if err != nil {
+			return nil, fmt.Errorf("ical: VTIMEZONE %q: %w", tzid, err)
+		}
+		reg.locations[tzid] = loc
+	}
+	return reg, nil
+}
+
+// Get returns the location for tzid, falling back to Go's IANA tzdata if
+// no VTIMEZONE defines it.
+func (reg *TimezoneRegistry) Get(tzid string) (*time.Location, error) {
+	if reg != nil {
+		if loc, ok := reg.locations[tzid]; ok {
+			return loc, nil
+		}
+	}
+	return time.LoadLocation(tzid)
+}
+
+// Timezone resolves tzid against the calendar's VTIMEZONE components,
+// falling back to Go's IANA tzdata.
+func (cal *Calendar) Timezone(tzid string) (*time.Location, error) {
+	reg, err := NewTimezoneRegistry(cal)
+	if err != nil {
+		return nil, err
+	}
+	return reg.Get(tzid)
+}
+
+// TZOffsetFrom returns the TZOFFSETFROM property of a STANDARD/DAYLIGHT
+// timezone subcomponent.
+func (comp *Component) TZOffsetFrom() (time.Duration, error) {
+	return comp.Props.UTCOffset(PropTimezoneOffsetFrom)
+}
+
+// TZOffsetTo returns the TZOFFSETTO property of a STANDARD/DAYLIGHT
+// timezone subcomponent.
+func (comp *Component) TZOffsetTo() (time.Duration, error) {
+	return comp.Props.UTCOffset(PropTimezoneOffsetTo)
+}
+
+type tzTransition struct {
+	at     time.Time
+	offset int // seconds east of UTC
+	name   string
+}
+
+// buildLocation expands the STANDARD/DAYLIGHT subcomponents of a VTIMEZONE
+// into a *time.Location backed by a fixed transition table.
+func buildLocation(tzid string, comp *Component) (*time.Location, error) {
+	var transitions []tzTransition
+
+	for _, sub := range comp.Children {
+		if sub.Name != CompTimezoneStandard && sub.Name != CompTimezoneDaylight {
+			continue
+		}
+
+		dtstart, err := sub.Props.DateTime(PropDateTimeStart, time.UTC)
+		if err != nil {
+			return nil, err
+		}
+		offsetTo, err := sub.TZOffsetTo()
+		if err != nil {
+			return nil, err
+		}
+		name, err := sub.Props.Text(PropTimezoneName)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			name = tzid
+		}
+
+		times := []time.Time{dtstart}
+		if rrule, err := sub.Props.RecurrenceRule(PropRecurrenceRule); err != nil {
+			return nil, err
+		} else if rrule != nil {
+			until := rrule.Until
+			if until.IsZero() {
+				until = time.Now().AddDate(50, 0, 0)
+			}
+			times = rrule.occurrences(dtstart, until)
+		}
+		for _, prop := range sub.Props[PropRecurrenceDates] {
+			ts, err := parseRDate(&prop, time.UTC)
+			if err != nil {
+				return nil, err
+			}
+			times = append(times, ts...)
+		}
+
+		for _, t := range times {
+			transitions = append(transitions, tzTransition{
+				at:     t,
+				offset: int(offsetTo / time.Second),
+				name:   name,
+			})
+		}
+	}
+
+	sort.Slice(transitions, func(i, j int) bool { return transitions[i].at.Before(transitions[j].at) })
+
+	return newTZifLocation(tzid, transitions)
+}
+
+// newTZifLocation synthesizes a *time.Location that actually implements the
+// given sorted list of transitions, by building a minimal TZif (tzfile(5))
+// v1 byte stream and handing it to time.LoadLocationFromTZData. The
+// standard library only exposes time.FixedZone for a single, unchanging
+// offset, which can't represent a VTIMEZONE with both STANDARD and
+// DAYLIGHT rules.
+func newTZifLocation(tzid string, transitions []tzTransition) (*time.Location, error) {
+	if len(transitions) == 0 {
+		return time.UTC, nil
+	}
+
+	type zoneType struct {
+		offset int
+		name   string
+	}
+	var types []zoneType
+	typeIndex := make(map[zoneType]int)
+	indexOf := func(zt zoneType) int {
+		if i, ok := typeIndex[zt]; ok {
+			return i
+		}
+		i := len(types)
+		types = append(types, zt)
+		typeIndex[zt] = i
+		return i
+	}
+
+	var buf []byte
+	writeUint32 := func(v uint32) {
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], v)
+		buf = append(buf, b[:]...)
+	}
+
+	txTimes := make([]int32, len(transitions))
+	txTypes := make([]byte, len(transitions))
+	for i, tr := range transitions {
+		txTimes[i] = clampToInt32(tr.at.Unix())
+		txTypes[i] = byte(indexOf(zoneType{offset: tr.offset, name: tr.name}))
+	}
+
+	var charData []byte
+	nameOffset := make(map[string]int)
+	for _, zt := range types {
+		if _, ok := nameOffset[zt.name]; ok {
+			continue
+		}
+		nameOffset[zt.name] = len(charData)
+		charData = append(charData, zt.name...)
+		charData = append(charData, 0)
+	}
+
+	buf = append(buf, "TZif"...)
+	buf = append(buf, 0) // version 1
+	buf = append(buf, make([]byte, 15)...)
+	writeUint32(0)                     // isutcnt
+	writeUint32(0)                     // isstdcnt
+	writeUint32(0)                     // leapcnt
+	writeUint32(uint32(len(txTimes)))  // timecnt
+	writeUint32(uint32(len(types)))    // typecnt
+	writeUint32(uint32(len(charData))) // charcnt
+
+	for _, t := range txTimes {
+		writeUint32(uint32(t))
+	}
+	buf = append(buf, txTypes...)
+
+	for _, zt := range types {
+		writeUint32(uint32(int32(zt.offset)))
+		buf = append(buf, 0) // isdst
+		buf = append(buf, byte(nameOffset[zt.name]))
+	}
+	buf = append(buf, charData...)
+
+	loc, err := time.LoadLocationFromTZData(tzid, buf)
+	if err != nil {
+		return nil, fmt.Errorf("ical: failed to synthesize timezone %q: %w", tzid, err)
+	}
+	return loc, nil
+}
+
+func clampToInt32(sec int64) int32 {
+	if sec > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	if sec < math.MinInt32 {
+		return math.MinInt32
+	}
+	return int32(sec)
+}
+
+// DateTimeIn parses the named date-time property, resolving its TZID
+// parameter against reg if the property carries one.
+func (props Props) DateTimeIn(name string, reg *TimezoneRegistry) (time.Time, error) {
+	prop := props.Get(name)
+	if prop == nil {
+		return time.Time{}, nil
+	}
+
+	loc := time.UTC
+	if tzid := prop.Params.Get(ParamTimezoneID); tzid != "" {
+		l, err := reg.Get(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("ical: unknown TZID %q: %w", tzid, err)
+		}
+		loc = l
+	}
+	return prop.DateTime(loc)
+}
+
+// DateTimeStartIn is like DateTimeStart, but resolves the DTSTART TZID
+// parameter against reg.
+func (e *Event) DateTimeStartIn(reg *TimezoneRegistry) (time.Time, error) {
+	return e.Props.DateTimeIn(PropDateTimeStart, reg)
+}
+
+// DateTimeEndIn is like DateTimeEnd, but resolves the DTEND TZID parameter
+// against reg.
+func (e *Event) DateTimeEndIn(reg *TimezoneRegistry) (time.Time, error) {
+	if prop := e.Props.Get(PropDateTimeEnd); prop != nil {
+		return e.Props.DateTimeIn(PropDateTimeEnd, reg)
+	}
+
+	start, err := e.DateTimeStartIn(reg)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var dur time.Duration
+	if durProp := e.Props.Get(PropDuration); durProp != nil {
+		dur, err = durProp.Duration()
+		if err != nil {
+			return time.Time{}, err
+		}
+	} else if startProp := e.Props.Get(PropDateTimeStart); startProp != nil && startProp.ValueType() == ValueDate {
+		dur = 24 * time.Hour
+	}
+
+	return start.Add(dur), nil
+}
+
+// NewTimezoneComponent emits a VTIMEZONE component describing loc's
+// transitions between from and to, suitable for embedding a portable
+// timezone definition in an encoded calendar rather than relying on the
+// receiver's own tzdata.
+func NewTimezoneComponent(tzid string, loc *time.Location, from, to time.Time) *Component {
+	comp := NewComponent(CompTimezone)
+	comp.Props.SetText(PropTimezoneID, tzid)
+
+	var prevName string
+	var prevOffset int
+	first := true
+
+	for t := from; !t.After(to); t = t.Add(24 * time.Hour) {
+		name, offset := t.In(loc).Zone()
+		if first || name != prevName || offset != prevOffset {
+			// A positive jump in offset relative to the previous rule is
+			// treated as a DAYLIGHT transition; this is a heuristic, since
+			// STANDARD/DAYLIGHT isn't recoverable from a bare offset table.
+			sub := NewComponent(CompTimezoneStandard)
+			if !first && offset > prevOffset {
+				sub = NewComponent(CompTimezoneDaylight)
+			}
+			sub.Props.SetDateTime(PropDateTimeStart, t)
+			sub.Props.SetUTCOffset(PropTimezoneOffsetFrom, time.Duration(prevOffset)*time.Second)
+			sub.Props.SetUTCOffset(PropTimezoneOffsetTo, time.Duration(offset)*time.Second)
+			sub.Props.SetText(PropTimezoneName, name)
+			comp.Children = append(comp.Children, sub)
+
+			prevName, prevOffset, first = name, offset, false
+		}
+	}
+
+	return comp
+}
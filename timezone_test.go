@@ -0,0 +1,75 @@
+package ical
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestTimezone builds a VTIMEZONE with alternating STANDARD/DAYLIGHT
+// rules loosely modeled on US Eastern time, to exercise multi-transition
+// resolution.
+func newTestTimezone(tzid string) *Component {
+	tz := NewComponent(CompTimezone)
+	tz.Props.SetText(PropTimezoneID, tzid)
+
+	std := NewComponent(CompTimezoneStandard)
+	std.Props.SetDateTime(PropDateTimeStart, time.Date(1970, 11, 1, 2, 0, 0, 0, time.UTC))
+	std.Props.SetUTCOffset(PropTimezoneOffsetFrom, -4*time.Hour)
+	std.Props.SetUTCOffset(PropTimezoneOffsetTo, -5*time.Hour)
+	std.Props.SetText(PropTimezoneName, "EST")
+	std.Props.SetRecurrenceRule(PropRecurrenceRule, mustParseRRuleForTZ("FREQ=YEARLY;BYMONTH=11;BYDAY=1SU"))
+
+	dst := NewComponent(CompTimezoneDaylight)
+	dst.Props.SetDateTime(PropDateTimeStart, time.Date(1970, 3, 8, 2, 0, 0, 0, time.UTC))
+	dst.Props.SetUTCOffset(PropTimezoneOffsetFrom, -5*time.Hour)
+	dst.Props.SetUTCOffset(PropTimezoneOffsetTo, -4*time.Hour)
+	dst.Props.SetText(PropTimezoneName, "EDT")
+	dst.Props.SetRecurrenceRule(PropRecurrenceRule, mustParseRRuleForTZ("FREQ=YEARLY;BYMONTH=3;BYDAY=2SU"))
+
+	tz.Children = append(tz.Children, std, dst)
+	return tz
+}
+
+func mustParseRRuleForTZ(s string) *RecurrenceRule {
+	rrule, err := ParseRecurrenceRule(s)
+	if err != nil {
+		panic(err)
+	}
+	return rrule
+}
+
+func TestTimezoneRegistryResolvesDSTTransitions(t *testing.T) {
+	cal := NewCalendar()
+	cal.Children = append(cal.Children, newTestTimezone("Test/Eastern"))
+
+	reg, err := NewTimezoneRegistry(cal)
+	if err != nil {
+		t.Fatalf("NewTimezoneRegistry() error = %v", err)
+	}
+
+	loc, err := reg.Get("Test/Eastern")
+	if err != nil {
+		t.Fatalf("reg.Get() error = %v", err)
+	}
+
+	winter := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC).In(loc)
+	if name, offset := winter.Zone(); name != "EST" || offset != -5*3600 {
+		t.Errorf("January: got zone %v %v, want EST -18000", name, offset)
+	}
+
+	summer := time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC).In(loc)
+	if name, offset := summer.Zone(); name != "EDT" || offset != -4*3600 {
+		t.Errorf("July: got zone %v %v, want EDT -14400", name, offset)
+	}
+}
+
+func TestCalendarTimezoneFallsBackToIANA(t *testing.T) {
+	cal := NewCalendar()
+	loc, err := cal.Timezone("UTC")
+	if err != nil {
+		t.Fatalf("Timezone() error = %v", err)
+	}
+	if loc != time.UTC {
+		t.Errorf("Timezone(%q) = %v, want UTC", "UTC", loc)
+	}
+}
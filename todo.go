@@ -0,0 +1,58 @@
+package ical
+
+import "fmt"
+
+// ToDoStatus is the status of a to-do, as defined in RFC 5545 section 3.8.1.11.
+type ToDoStatus string
+
+// To-do statuses.
+const (
+	ToDoNeedsAction ToDoStatus = "NEEDS-ACTION"
+	ToDoCompleted   ToDoStatus = "COMPLETED"
+	ToDoInProcess   ToDoStatus = "IN-PROCESS"
+	ToDoCancelled   ToDoStatus = "CANCELLED"
+)
+
+// ToDo represents an action item or assignment, as defined in RFC 5545
+// section 3.6.2.
+type ToDo struct {
+	*Component
+}
+
+// NewToDo creates a new to-do.
+func NewToDo() *ToDo {
+	return &ToDo{NewComponent(CompToDo)}
+}
+
+// ToDos extracts the list of to-dos contained in the calendar.
+func (cal *Calendar) ToDos() []ToDo {
+	l := make([]ToDo, 0, len(cal.Children))
+	for _, child := range cal.Children {
+		if child.Name == CompToDo {
+			l = append(l, ToDo{child})
+		}
+	}
+	return l
+}
+
+func (t *ToDo) Status() (ToDoStatus, error) {
+	s, err := t.Props.Text(PropStatus)
+	if err != nil {
+		return "", err
+	}
+
+	switch status := ToDoStatus(s); status {
+	case "", ToDoNeedsAction, ToDoCompleted, ToDoInProcess, ToDoCancelled:
+		return status, nil
+	default:
+		return "", fmt.Errorf("ical: invalid VTODO STATUS: %q", status)
+	}
+}
+
+func (t *ToDo) SetStatus(status ToDoStatus) {
+	if status == "" {
+		t.Props.Del(PropStatus)
+	} else {
+		t.Props.SetText(PropStatus, string(status))
+	}
+}
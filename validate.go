@@ -0,0 +1,238 @@
+package ical
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ValidationError is a single RFC 5545 conformance failure found by
+// Calendar.Validate.
+type ValidationError struct {
+	Path     []string // component names from VCALENDAR down to the offending component
+	Property string   // property name, empty if the error isn't property-specific
+	Code     string   // stable machine-readable identifier
+	Message  string
+}
+
+func (e *ValidationError) Error() string {
+	where := strings.Join(e.Path, "/")
+	if e.Property != "" {
+		where += "." + e.Property
+	}
+	return fmt.Sprintf("ical: %v: %v", where, e.Message)
+}
+
+// Validation error codes.
+const (
+	ErrCodeMissingProp   = "missing-property"
+	ErrCodeDuplicateProp = "duplicate-property"
+	ErrCodeBadRange      = "bad-range"
+	ErrCodeConflictProp  = "conflicting-properties"
+	ErrCodeBadParam      = "bad-parameter"
+)
+
+// Validate walks the calendar and reports every RFC 5545 cardinality and
+// consistency violation it finds. It does not stop at the first error.
+func (cal *Calendar) Validate() []ValidationError {
+	var errs []ValidationError
+	v := validator{errs: &errs}
+	v.validateCalendar(cal)
+	return errs
+}
+
+type validator struct {
+	errs *[]ValidationError
+}
+
+func (v *validator) fail(path []string, property, code, message string) {
+	*v.errs = append(*v.errs, ValidationError{
+		Path:     append([]string(nil), path...),
+		Property: property,
+		Code:     code,
+		Message:  message,
+	})
+}
+
+func (v *validator) validateCalendar(cal *Calendar) {
+	path := []string{cal.Name}
+
+	v.requireExactlyOne(path, cal.Component, PropProductID)
+	v.requireExactlyOne(path, cal.Component, PropVersion)
+
+	uids := map[string]bool{}
+	for _, child := range cal.Children {
+		childPath := append(path, child.Name)
+		switch child.Name {
+		case CompEvent:
+			v.validateEvent(childPath, child, uids)
+		case CompToDo, CompJournal, CompFreeBusy:
+			v.requireExactlyOne(childPath, child, PropUID)
+			v.requireExactlyOne(childPath, child, PropDateTimeStamp)
+		case CompTimezone:
+			v.requireExactlyOne(childPath, child, PropTimezoneID)
+		}
+	}
+}
+
+func (v *validator) validateEvent(path []string, comp *Component, uids map[string]bool) {
+	v.requireExactlyOne(path, comp, PropUID)
+	v.requireExactlyOne(path, comp, PropDateTimeStamp)
+
+	if uid, err := comp.Props.Text(PropUID); err == nil && uid != "" {
+		if comp.Props.Get(PropRecurrenceID) == nil {
+			if uids[uid] {
+				v.fail(path, PropUID, ErrCodeDuplicateProp, "duplicate UID across master VEVENTs")
+			}
+			uids[uid] = true
+		}
+	}
+
+	dtend := comp.Props.Get(PropDateTimeEnd)
+	duration := comp.Props.Get(PropDuration)
+	if dtend != nil && duration != nil {
+		v.fail(path, PropDateTimeEnd, ErrCodeConflictProp, "DTEND and DURATION are mutually exclusive")
+	}
+
+	if dtstartProp := comp.Props.Get(PropDateTimeStart); dtstartProp != nil && dtend != nil {
+		dtstart, err1 := dtstartProp.DateTime(time.UTC)
+		end, err2 := dtend.DateTime(time.UTC)
+		if err1 == nil && err2 == nil && !end.After(dtstart) {
+			v.fail(path, PropDateTimeEnd, ErrCodeBadRange, "DTEND must be after DTSTART")
+		}
+	}
+
+	if attach := comp.Props.Get(PropAttach); attach != nil {
+		v.validateAttach(path, attach)
+	}
+
+	for _, alarm := range comp.Children {
+		if alarm.Name != CompAlarm {
+			continue
+		}
+		v.validateAlarm(append(path, alarm.Name), alarm)
+	}
+}
+
+func (v *validator) validateAttach(path []string, prop *Prop) {
+	if prop.ValueType() != ValueBinary {
+		return
+	}
+	if prop.Params.Get(ParamEncoding) != "BASE64" {
+		v.fail(path, PropAttach, ErrCodeBadParam, "ATTACH with VALUE=BINARY requires ENCODING=BASE64")
+	}
+}
+
+func (v *validator) validateAlarm(path []string, comp *Component) {
+	action, err := comp.Props.Text(PropAction)
+	if err != nil || action == "" {
+		v.fail(path, PropAction, ErrCodeMissingProp, "VALARM is missing ACTION")
+		return
+	}
+
+	switch AlarmAction(action) {
+	case ActionDisplay:
+		v.requireExactlyOne(path, comp, PropDescription)
+	case ActionEmail:
+		v.requireExactlyOne(path, comp, PropDescription)
+		v.requireExactlyOne(path, comp, PropSummary)
+		if len(comp.Props[PropAttendee]) == 0 {
+			v.fail(path, PropAttendee, ErrCodeMissingProp, "VALARM with ACTION=EMAIL requires at least one ATTENDEE")
+		}
+	}
+
+	if comp.Props.Get(PropTrigger) == nil {
+		v.fail(path, PropTrigger, ErrCodeMissingProp, "VALARM is missing TRIGGER")
+	}
+}
+
+func (v *validator) requireExactlyOne(path []string, comp *Component, name string) {
+	switch n := len(comp.Props[name]); {
+	case n == 0:
+		v.fail(path, name, ErrCodeMissingProp, fmt.Sprintf("%v is required", name))
+	case n > 1:
+		v.fail(path, name, ErrCodeDuplicateProp, fmt.Sprintf("%v must not be repeated", name))
+	}
+}
+
+// Normalize brings the calendar into a canonical form: it upper-cases
+// parameter names, collapses duplicate VTIMEZONEs sharing a TZID, fills in
+// missing DTSTAMP/UID, defaults CALSCALE to GREGORIAN, and drops VALUE
+// parameters that match the property's default type. Params is a map, so
+// Normalize does not order parameters; Encoder writes them sorted by name.
+func (cal *Calendar) Normalize() {
+	if cal.Props.Get(PropCalendarScale) == nil {
+		cal.Props.SetText(PropCalendarScale, "GREGORIAN")
+	}
+
+	normalizeComponent(cal.Component)
+	cal.Children = collapseTimezones(cal.Children)
+
+	for _, child := range cal.Children {
+		switch child.Name {
+		case CompEvent, CompToDo, CompJournal, CompFreeBusy:
+			normalizeUIDAndStamp(child)
+		}
+	}
+}
+
+func normalizeComponent(comp *Component) {
+	for name, props := range comp.Props {
+		for i := range props {
+			prop := &props[i]
+			normalizeParams(prop)
+			if t, ok := defaultValueTypes[prop.Name]; ok && prop.ValueType() == t {
+				prop.Params.Del(ParamValue)
+			}
+		}
+		comp.Props[name] = props
+	}
+	for _, child := range comp.Children {
+		normalizeComponent(child)
+	}
+}
+
+func normalizeParams(prop *Prop) {
+	upper := make(Params, len(prop.Params))
+	for name, values := range prop.Params {
+		upper[strings.ToUpper(name)] = values
+	}
+	prop.Params = upper
+}
+
+func collapseTimezones(children []*Component) []*Component {
+	seen := map[string]bool{}
+	out := make([]*Component, 0, len(children))
+	for _, child := range children {
+		if child.Name != CompTimezone {
+			out = append(out, child)
+			continue
+		}
+		tzid, _ := child.Props.Text(PropTimezoneID)
+		if seen[tzid] {
+			continue
+		}
+		seen[tzid] = true
+		out = append(out, child)
+	}
+	return out
+}
+
+func normalizeUIDAndStamp(comp *Component) {
+	if comp.Props.Get(PropUID) == nil {
+		comp.Props.SetText(PropUID, generateUID())
+	}
+	if comp.Props.Get(PropDateTimeStamp) == nil {
+		comp.Props.SetDateTime(PropDateTimeStamp, time.Now().UTC())
+	}
+}
+
+func generateUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%d@go-ical", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:]) + "@go-ical"
+}